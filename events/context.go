@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// EmitContext fires callbacks asynchronously, exactly like Emit, but
+// propagates ctx to any handler whose first parameter is
+// context.Context (e.g. func(ctx context.Context, name string)).
+// Each queued handler is skipped, rather than run, once ctx is
+// cancelled - see runJob in dispatch.go. The returned error is always
+// nil; it exists for symmetry with EmitSyncContext.
+func (e *Emitter) EmitContext(ctx context.Context, topic string, args ...interface{}) error {
+	return e.emit(false, ctx, topic, args...)
+}
+
+// EmitSyncContext fires callbacks synchronously, exactly like
+// EmitSync, but propagates ctx to any handler whose first parameter
+// is context.Context. The emit returns as soon as either every
+// handler has finished or ctx.Done() fires, whichever comes first; in
+// the latter case it returns ctx.Err() without waiting on any
+// handlers still in flight. See EmitterOptions.HandlerTimeout for a
+// similar, per-handler bound that doesn't require a context.
+func (e *Emitter) EmitSyncContext(ctx context.Context, topic string, args ...interface{}) error {
+	return e.emit(true, ctx, topic, args...)
+}
+
+// runSync runs invs in order, waiting for each to finish before
+// starting the next, unless ctx is cancelled or HandlerTimeout
+// elapses first - in which case it abandons the in-flight handler
+// (which keeps running in the background; Go can't preempt it) and
+// returns. ctx is nil for plain EmitSync calls. topic is only used to
+// identify a panicking handler to PanicHandler.
+func (e *Emitter) runSync(ctx context.Context, topic string, invs []invocation) error {
+	if ctx == nil && e.opts.HandlerTimeout <= 0 {
+		// Fast path: nothing to select on, so just call handlers in
+		// order like EmitSync always has - a panic here unwinds
+		// straight up the caller's own goroutine, same as before
+		// HandlerTimeout/EmitSyncContext existed.
+		callInvocationsSync(invs)
+		return nil
+	}
+
+	for _, inv := range invs {
+		if err := e.runOneSync(ctx, topic, inv); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Emitter) runOneSync(ctx context.Context, topic string, inv invocation) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				e.opts.PanicHandler(topic, r)
+			}
+		}()
+		inv.call()
+	}()
+
+	var timeout <-chan time.Time
+	if e.opts.HandlerTimeout > 0 {
+		timer := time.NewTimer(e.opts.HandlerTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var cancelled <-chan struct{}
+	if ctx != nil {
+		cancelled = ctx.Done()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-timeout:
+		return nil
+	case <-cancelled:
+		return ctx.Err()
+	}
+}
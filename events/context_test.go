@@ -0,0 +1,62 @@
+package events_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wadeAlexC/go-events/events"
+)
+
+// TestEmitSyncHandlerTimeoutPanicRecovered is a regression test: a
+// panicking handler run through the HandlerTimeout/EmitSyncContext
+// path (runOneSync, which calls the handler on its own goroutine) used
+// to crash the whole process instead of being routed through
+// PanicHandler like the async worker pool does.
+func TestEmitSyncHandlerTimeoutPanicRecovered(t *testing.T) {
+	var mu sync.Mutex
+	var caught string
+
+	e := events.NewEmitter(events.EmitterOptions{
+		HandlerTimeout: time.Second,
+		PanicHandler: func(topic string, r interface{}) {
+			mu.Lock()
+			caught = topic
+			mu.Unlock()
+		},
+	})
+
+	e.On("t", func() { panic("boom") })
+	e.EmitSync("t")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if caught != "t" {
+		t.Fatalf("PanicHandler not invoked for panicking handler under HandlerTimeout, caught = %q", caught)
+	}
+}
+
+// TestEmitSyncContextPanicRecovered is the same regression, exercised
+// via EmitSyncContext instead of HandlerTimeout.
+func TestEmitSyncContextPanicRecovered(t *testing.T) {
+	var mu sync.Mutex
+	var caught string
+
+	e := events.NewEmitter(events.EmitterOptions{
+		PanicHandler: func(topic string, r interface{}) {
+			mu.Lock()
+			caught = topic
+			mu.Unlock()
+		},
+	})
+
+	e.On("t", func() { panic("boom") })
+	_ = e.EmitSyncContext(context.Background(), "t")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if caught != "t" {
+		t.Fatalf("PanicHandler not invoked for panicking handler under EmitSyncContext, caught = %q", caught)
+	}
+}
@@ -0,0 +1,342 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy controls what Emit does when the async dispatch
+// queue is full.
+type OverflowPolicy int
+
+const (
+	// PolicyBlock waits for room in the queue, applying backpressure
+	// to the emitting goroutine. This is the default.
+	PolicyBlock OverflowPolicy = iota
+	// PolicyDropNewest discards the job that just failed to enqueue,
+	// leaving everything already queued untouched.
+	PolicyDropNewest
+	// PolicyDropOldest discards the oldest queued job to make room
+	// for the new one, favoring recent events over stale ones.
+	PolicyDropOldest
+	// PolicyPanic panics the emitting goroutine when the queue is full.
+	PolicyPanic
+)
+
+const (
+	// DefaultWorkers is the worker pool size used when
+	// EmitterOptions.Workers is left unset.
+	DefaultWorkers = 16
+	// DefaultQueueSize is the job queue capacity used when
+	// EmitterOptions.QueueSize is left unset.
+	DefaultQueueSize = 256
+)
+
+// PanicHandler is called, instead of crashing the process, when a
+// handler invoked via Emit panics.
+type PanicHandler func(topic string, r interface{})
+
+// EmitterOptions configures an Emitter's dispatch. Workers and
+// QueueSize size the bounded worker pool backing Emit/EmitContext.
+// Policy applies to that pool's job queue and to Subscribe channel
+// deliveries from every emit path, EmitSync included. PanicHandler and
+// HandlerTimeout also apply to EmitSync/EmitSyncContext whenever they
+// run handlers under a timeout or a context - see runSync in context.go.
+type EmitterOptions struct {
+	// Workers is the number of goroutines draining the job queue.
+	// Defaults to DefaultWorkers.
+	Workers int
+	// QueueSize is the job queue's capacity. Defaults to DefaultQueueSize.
+	QueueSize int
+	// Policy controls what happens when the job queue is full.
+	// Defaults to PolicyBlock.
+	Policy OverflowPolicy
+	// PanicHandler is called with the topic and recovered value when
+	// a dispatched handler panics. Defaults to logging via fmt.Printf,
+	// so a panicking listener can no longer silently kill an anonymous
+	// goroutine.
+	PanicHandler PanicHandler
+	// HandlerTimeout, if set, bounds how long EmitSync/EmitSyncContext
+	// wait on any single handler before moving on to the next one. The
+	// handler itself isn't killed - Go can't preempt a running
+	// goroutine - it's simply abandoned so a slow or stuck handler
+	// can't stall the sync path forever. Zero means no timeout.
+	HandlerTimeout time.Duration
+}
+
+// DefaultEmitterOptions returns the EmitterOptions used by
+// NewEmitter() when none are supplied.
+func DefaultEmitterOptions() EmitterOptions {
+	return EmitterOptions{
+		Workers:   DefaultWorkers,
+		QueueSize: DefaultQueueSize,
+		Policy:    PolicyBlock,
+	}
+}
+
+func (o *EmitterOptions) setDefaults() {
+	if o.Workers <= 0 {
+		o.Workers = DefaultWorkers
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = DefaultQueueSize
+	}
+	if o.PanicHandler == nil {
+		o.PanicHandler = defaultPanicHandler
+	}
+}
+
+func defaultPanicHandler(topic string, r interface{}) {
+	fmt.Printf("T[%s] listener panicked: %v\n", topic, r)
+}
+
+// job is one unit of work queued up for a worker to run: either a
+// handler invocation (inv) or a Subscribe channel delivery (send) -
+// never both. ctx is nil unless the emit that produced it came from
+// EmitContext.
+type job struct {
+	topic string
+	ctx   context.Context
+	inv   invocation
+	send  *chanSend
+}
+
+// chanSend is one Subscribe channel delivery queued up by an emit.
+type chanSend struct {
+	sub  *chanSub
+	args []interface{}
+}
+
+// Metrics is a point-in-time snapshot of an Emitter's async dispatch
+// activity.
+type Metrics struct {
+	// EmittedTotal counts jobs successfully queued onto the worker pool
+	// (handler invocations and Subscribe channel deliveries alike), plus
+	// every Subscribe channel delivery actually sent. A channel delivery
+	// made via Emit/EmitContext is therefore counted twice: once when
+	// queued and once when sent.
+	EmittedTotal uint64
+	// DroppedTotal counts jobs discarded under PolicyDropNewest or
+	// PolicyDropOldest, plus Subscribe channel deliveries discarded
+	// because the channel was full (under those two policies) or already
+	// closed (under any policy).
+	DroppedTotal uint64
+	// QueueDepth is the number of jobs currently queued, waiting for
+	// a worker.
+	QueueDepth int
+}
+
+// Metrics returns a snapshot of this Emitter's async dispatch counters.
+func (e *Emitter) Metrics() Metrics {
+	return Metrics{
+		EmittedTotal: atomic.LoadUint64(&e.emittedTotal),
+		DroppedTotal: atomic.LoadUint64(&e.droppedTotal),
+		QueueDepth:   len(e.jobs),
+	}
+}
+
+func (e *Emitter) worker() {
+	for j := range e.jobs {
+		e.runJob(j)
+	}
+}
+
+func (e *Emitter) runJob(j job) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.opts.PanicHandler(j.topic, r)
+		}
+	}()
+
+	// An EmitContext caller that's already given up by the time a
+	// worker gets to this job shouldn't have its handler run (or its
+	// channel sent to) at all.
+	if j.ctx != nil {
+		select {
+		case <-j.ctx.Done():
+			return
+		default:
+		}
+	}
+
+	if j.send != nil {
+		e.sendToChan(j.ctx, j.topic, j.send.sub, j.send.args)
+		return
+	}
+
+	j.inv.call()
+}
+
+// dispatchAsync queues invs for handling by the worker pool,
+// applying the emitter's overflow policy as each job is enqueued. ctx
+// is nil unless this came from EmitContext, in which case each queued
+// job is skipped once ctx is cancelled - see runJob.
+func (e *Emitter) dispatchAsync(ctx context.Context, topic string, invs []invocation) {
+	for _, inv := range invs {
+		e.enqueue(job{topic: topic, ctx: ctx, inv: inv})
+	}
+}
+
+// dispatchChansAsync queues Subscribe channel deliveries for handling
+// by the worker pool, same as dispatchAsync does for handler
+// invocations - so a full/slow consumer channel only ever blocks a
+// worker goroutine, never the emitting caller.
+func (e *Emitter) dispatchChansAsync(ctx context.Context, topic string, sends []chanSend) {
+	for i := range sends {
+		e.enqueue(job{topic: topic, ctx: ctx, send: &sends[i]})
+	}
+}
+
+func (e *Emitter) enqueue(j job) {
+	switch e.opts.Policy {
+	case PolicyDropNewest:
+		select {
+		case e.jobs <- j:
+			atomic.AddUint64(&e.emittedTotal, 1)
+		default:
+			atomic.AddUint64(&e.droppedTotal, 1)
+		}
+
+	case PolicyDropOldest:
+		select {
+		case e.jobs <- j:
+			atomic.AddUint64(&e.emittedTotal, 1)
+		default:
+			select {
+			case <-e.jobs:
+				atomic.AddUint64(&e.droppedTotal, 1)
+			default:
+			}
+			e.jobs <- j
+			atomic.AddUint64(&e.emittedTotal, 1)
+		}
+
+	case PolicyPanic:
+		select {
+		case e.jobs <- j:
+			atomic.AddUint64(&e.emittedTotal, 1)
+		default:
+			panicF("T[%s] emit queue is full", j.topic)
+		}
+
+	default: // PolicyBlock
+		e.jobs <- j
+		atomic.AddUint64(&e.emittedTotal, 1)
+	}
+}
+
+// trySend makes one non-blocking attempt to deliver args on cs.ch,
+// reporting whether it was sent. closed reports specifically that cs
+// had already been closed. trySend holds cs.mu for the whole attempt -
+// the same lock chanSub.close takes to close cs.ch - so a send and a
+// concurrent Unsubscribe/RemoveAllListeners on cs can never interleave:
+// the close either fully happens-before this check (closed is true,
+// nothing is sent) or fully happens-after it (the send completes
+// first). Either way there's no window where ch is sent to and closed
+// at the same time.
+func trySend(cs *chanSub, args []interface{}) (sent, closed bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.closed {
+		return false, true
+	}
+
+	select {
+	case cs.ch <- args:
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+// blockingSend delivers args on cs.ch, waiting until either the send
+// succeeds or ctx (if non-nil) is done. See trySend for why this holds
+// cs.mu for the duration - the one consequence is that a concurrent
+// Unsubscribe/RemoveAllListeners on cs waits for this send to resolve
+// before it can close, same as Emit itself already blocks indefinitely
+// under PolicyBlock with no ctx and no receiver.
+func blockingSend(ctx context.Context, cs *chanSub, args []interface{}) (sent, closed bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.closed {
+		return false, true
+	}
+
+	if ctx == nil {
+		cs.ch <- args
+		return true, false
+	}
+
+	select {
+	case cs.ch <- args:
+		return true, false
+	case <-ctx.Done():
+		return false, false
+	}
+}
+
+// sendToChan delivers args to a Subscribe channel, applying the same
+// overflow policy as enqueue applies to the worker pool - so a
+// consumer that never drains its channel behaves exactly like a
+// stalled handler: dropped, bumped out in favor of the new event, or
+// fatal under PolicyPanic. Under PolicyBlock it blocks, same as
+// enqueue - but ctx, if non-nil, unblocks it early, the same as a
+// cancelled EmitContext/EmitSyncContext abandons an in-flight handler.
+func (e *Emitter) sendToChan(ctx context.Context, topic string, cs *chanSub, args []interface{}) {
+	switch e.opts.Policy {
+	case PolicyDropNewest:
+		if sent, _ := trySend(cs, args); sent {
+			atomic.AddUint64(&e.emittedTotal, 1)
+		} else {
+			atomic.AddUint64(&e.droppedTotal, 1)
+		}
+
+	case PolicyDropOldest:
+		if sent, closed := trySend(cs, args); sent {
+			atomic.AddUint64(&e.emittedTotal, 1)
+			return
+		} else if closed {
+			atomic.AddUint64(&e.droppedTotal, 1)
+			return
+		}
+
+		select {
+		case <-cs.ch:
+			atomic.AddUint64(&e.droppedTotal, 1)
+		default:
+		}
+
+		if sent, _ := trySend(cs, args); sent {
+			atomic.AddUint64(&e.emittedTotal, 1)
+		} else {
+			atomic.AddUint64(&e.droppedTotal, 1)
+		}
+
+	case PolicyPanic:
+		switch sent, closed := trySend(cs, args); {
+		case sent:
+			atomic.AddUint64(&e.emittedTotal, 1)
+		case closed:
+			// The subscriber already unsubscribed - nothing to panic
+			// about, the channel just isn't there to deliver to.
+			atomic.AddUint64(&e.droppedTotal, 1)
+		default:
+			panicF("T[%s] subscriber channel is full", topic)
+		}
+
+	default: // PolicyBlock
+		switch sent, closed := blockingSend(ctx, cs, args); {
+		case sent:
+			atomic.AddUint64(&e.emittedTotal, 1)
+		case closed:
+			atomic.AddUint64(&e.droppedTotal, 1)
+		default:
+			// ctx was cancelled - abandoned, same as a timed-out
+			// handler under HandlerTimeout.
+		}
+	}
+}
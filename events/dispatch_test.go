@@ -0,0 +1,155 @@
+package events_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wadeAlexC/go-events/events"
+)
+
+// TestEmitRunsOnWorkerPool verifies that Emit dispatches to a handler
+// through the worker pool's job queue, counting the queued job in
+// Metrics().EmittedTotal.
+func TestEmitRunsOnWorkerPool(t *testing.T) {
+	e := events.NewEmitter()
+
+	done := make(chan struct{})
+	e.On("t", func() { close(done) })
+
+	e.Emit("t")
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Emit never dispatched to the handler")
+	}
+
+	m := e.Metrics()
+	if m.EmittedTotal != 1 {
+		t.Fatalf("Metrics().EmittedTotal = %d, want 1", m.EmittedTotal)
+	}
+}
+
+// blockingEmitter returns an Emitter with a single worker and a
+// one-slot queue, plus a handler already occupying that one worker -
+// so the next Emit fills the queue and the one after that overflows
+// it. Call release to free the worker once the test is done observing
+// overflow behavior.
+func blockingEmitter(t *testing.T, policy events.OverflowPolicy) (e *events.Emitter, release func()) {
+	t.Helper()
+
+	block := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	e = events.NewEmitter(events.EmitterOptions{
+		Workers:   1,
+		QueueSize: 1,
+		Policy:    policy,
+	})
+	e.On("t", func() {
+		started <- struct{}{}
+		<-block
+	})
+
+	e.Emit("t") // picked up by the sole worker immediately
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("worker never started the first job")
+	}
+
+	return e, func() { close(block) }
+}
+
+// TestPolicyDropNewestDropsOverflow verifies that under
+// PolicyDropNewest, a job that doesn't fit in the queue is dropped and
+// counted in DroppedTotal, while the jobs that did fit still run.
+func TestPolicyDropNewestDropsOverflow(t *testing.T) {
+	e, release := blockingEmitter(t, events.PolicyDropNewest)
+	defer release()
+
+	e.Emit("t") // fills the one queue slot
+	e.Emit("t") // queue is full - dropped
+
+	m := e.Metrics()
+	if m.DroppedTotal != 1 {
+		t.Fatalf("Metrics().DroppedTotal = %d, want 1", m.DroppedTotal)
+	}
+	if m.QueueDepth != 1 {
+		t.Fatalf("Metrics().QueueDepth = %d, want 1", m.QueueDepth)
+	}
+}
+
+// TestPolicyDropOldestEvictsOldest verifies that under
+// PolicyDropOldest, a job that doesn't fit in the queue bumps out the
+// oldest queued job instead of being dropped itself - so the queue
+// stays at capacity and the total drop count still increases by
+// exactly one.
+func TestPolicyDropOldestEvictsOldest(t *testing.T) {
+	e, release := blockingEmitter(t, events.PolicyDropOldest)
+	defer release()
+
+	e.Emit("t") // fills the one queue slot
+	e.Emit("t") // bumps the queued job out to make room for this one
+
+	m := e.Metrics()
+	if m.DroppedTotal != 1 {
+		t.Fatalf("Metrics().DroppedTotal = %d, want 1", m.DroppedTotal)
+	}
+	if m.QueueDepth != 1 {
+		t.Fatalf("Metrics().QueueDepth = %d, want 1 (the newer job took the slot)", m.QueueDepth)
+	}
+}
+
+// TestPolicyPanicPanicsOnFullQueue verifies that under PolicyPanic,
+// Emit itself panics the caller once the queue is full, rather than
+// dropping the job or blocking.
+func TestPolicyPanicPanicsOnFullQueue(t *testing.T) {
+	e, release := blockingEmitter(t, events.PolicyPanic)
+	defer release()
+
+	e.Emit("t") // fills the one queue slot
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Emit did not panic on a full queue under PolicyPanic")
+		}
+		if msg, ok := r.(string); !ok || !strings.Contains(msg, "queue is full") {
+			t.Fatalf("panic value = %v, want a message mentioning the full queue", r)
+		}
+	}()
+
+	e.Emit("t") // queue is full - should panic the caller
+}
+
+// TestPolicyBlockAppliesBackpressure verifies that under the default
+// PolicyBlock, Emit blocks the caller once the queue is full, rather
+// than dropping the job or panicking, and unblocks once room frees up.
+func TestPolicyBlockAppliesBackpressure(t *testing.T) {
+	e, release := blockingEmitter(t, events.PolicyBlock)
+
+	e.Emit("t") // fills the one queue slot
+
+	done := make(chan struct{})
+	go func() {
+		e.Emit("t") // queue is full - should block here
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Emit returned immediately instead of blocking on a full queue")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Emit never unblocked once the worker drained the queue")
+	}
+}
@@ -1,23 +1,50 @@
 package events
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 )
 
+// ctxType is context.Context's interface type, used to recognize
+// handlers whose first parameter should receive the context.Context
+// passed to EmitContext/EmitSyncContext.
+var ctxType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 type EventEmitter interface {
-	On(topic string, handler interface{})
-	Once(topic string, handler interface{})
+	On(topic string, handler interface{}) Subscription
+	Once(topic string, handler interface{}) Subscription
+	OnPriority(topic string, priority int, handler interface{}) Subscription
+	Subscribe(topic string, buf int) (<-chan []interface{}, Subscription)
+
+	Off(topic string, handler interface{})
+	RemoveAllListeners(topic string)
+	ListenerCount(topic string) int
 
 	Emit(topic string, args ...interface{})
 	EmitSync(topic string, args ...interface{})
+
+	EmitContext(ctx context.Context, topic string, args ...interface{}) error
+	EmitSyncContext(ctx context.Context, topic string, args ...interface{}) error
 }
 
 type Emitter struct {
-	// Map topics to callbacks
-	mu        sync.Mutex
-	listeners map[string]*Handlers
+	// Trie of topics to callbacks, keyed segment-by-segment so that
+	// wildcard topics ("thing.*", "**") can fan out to every matching
+	// literal topic. See trie.go.
+	mu     sync.Mutex
+	root   *topicNode
+	nextID uint64
+
+	// Bounded worker pool backing Emit's async dispatch, plus the
+	// counters behind Metrics(). See dispatch.go.
+	opts         EmitterOptions
+	jobs         chan job
+	emittedTotal uint64
+	droppedTotal uint64
 }
 
 var _ EventEmitter = (*Emitter)(nil)
@@ -29,37 +56,338 @@ type Handlers struct {
 	mu    sync.Mutex
 	in    []reflect.Type // Lists the types of the parameters to the handler functions
 	funcs []HandlerFunc  // The handler functions
+	chans []*chanSub     // The channel subscribers, see Subscribe
+
+	// wildcard is true when this Handlers is reached via a "*" or
+	// "**" segment rather than an exact topic. Exact-topic handlers
+	// must all share one signature (in), enforced at registration;
+	// wildcard handlers may not, since a single pattern can match
+	// emits with entirely different argument lists, so each is
+	// checked against the emitted args individually when it fires.
+	wildcard bool
+
+	// wantsContext is true when in[0] is context.Context, meaning
+	// these handlers must be fired via EmitContext/EmitSyncContext so
+	// there's a context to pass them.
+	wantsContext bool
+
+	// sigSet is true once in/wantsContext have been fixed by the
+	// first func handler registered on an exact topic. Subscribe can
+	// create a Handlers node before any func handler exists (it has
+	// no signature of its own - it just forwards raw args), so the
+	// signature isn't established at node-creation time, only at the
+	// first On/Once/OnPriority.
+	sigSet bool
+}
+
+// chanSub is a single channel subscriber registered via Subscribe. Its
+// id lives in the same namespace as HandlerFunc.id, so Subscription
+// and removeByID work unchanged for both.
+//
+// handlers.chans holds *chanSub rather than chanSub by value so that
+// every copy made while queuing a delivery (chanSend, job) shares the
+// same mu/closed pair as the original. mu serializes every send
+// attempt on ch with this chanSub's own close - ch is only ever sent
+// to or closed while holding mu - so Unsubscribe/RemoveAllListeners
+// can never race a worker's delivery into a send-on-a-closed-channel
+// panic; see trySend/blockingSend/close in dispatch.go.
+type chanSub struct {
+	id     uint64
+	ch     chan []interface{}
+	mu     sync.Mutex
+	closed bool
+}
+
+// close closes ch at most once, synchronized with any in-flight send
+// so the two can never race. A send already blocked waiting for a
+// receiver (PolicyBlock with no ctx) delays the close until that send
+// resolves, same as Unsubscribe already implicitly waits on a full
+// queue under PolicyBlock elsewhere - see sendToChan in dispatch.go.
+func (cs *chanSub) close() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if !cs.closed {
+		cs.closed = true
+		close(cs.ch)
+	}
 }
 
 type HandlerFunc struct {
-	once bool
-	f    reflect.Value
+	id       uint64
+	priority int
+	once     bool
+	f        reflect.Value
+	in       []reflect.Type
+	variadic bool
+}
+
+// Subscription is a handle to a single registered handler, returned
+// by On/Once. Calling Unsubscribe detaches that specific handler -
+// and only that handler - even if other callbacks are registered
+// for the same topic.
+type Subscription struct {
+	topic string
+	id    uint64
+	e     *Emitter
 }
 
-func NewEmitter() *Emitter {
-	return &Emitter{
-		listeners: make(map[string]*Handlers),
+// Unsubscribe removes the handler this Subscription was issued for.
+// It is a no-op if the handler has already been removed, e.g. because
+// it was registered with Once and has already fired.
+func (s Subscription) Unsubscribe() {
+	s.e.removeByID(s.topic, s.id)
+}
+
+// NewEmitter creates an Emitter. By default, Emit dispatches to a
+// bounded pool of DefaultWorkers goroutines with a DefaultQueueSize
+// job queue and PolicyBlock backpressure; pass an EmitterOptions to
+// override any of that - see dispatch.go.
+func NewEmitter(opts ...EmitterOptions) *Emitter {
+	o := DefaultEmitterOptions()
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.setDefaults()
+
+	e := &Emitter{
+		root: newTopicNode(),
+		opts: o,
+		jobs: make(chan job, o.QueueSize),
+	}
+
+	for i := 0; i < o.Workers; i++ {
+		go e.worker()
 	}
+
+	return e
 }
 
 /// REGISTERING CALLBACKS:
 //
 // On/Once register a callback function for a topic.
 //
+// Topics may be hierarchical, with segments delimited by '.' or '/'
+// (e.g. "thing.value.updated"). A segment of "*" subscribes to every
+// topic one level deeper ("thing.*" matches "thing.ready" and
+// "thing.valued"); a segment of "**" subscribes to everything from
+// that point down ("**" matches every topic at all; "thing.**"
+// matches "thing", "thing.ready", "thing.a.b", ...).
+//
 // Requirements:
 // 1. The param types and positions of each subsequent callback
-//    must be the same as the other callbacks registered for
-//    the same topic.
-
-// On registers a callback function for some topic
-func (e *Emitter) On(topic string, handler interface{}) {
-	e.addHandler(false, topic, handler)
+//    registered for the same *exact* (non-wildcard) topic must match
+//    the other callbacks already registered for that topic. Wildcard
+//    handlers are exempt from this, since one pattern can match many
+//    differently-shaped emits - see Emit below.
+//
+// Handlers registered on the same topic string fire in priority order -
+// highest first - with ties (including the default priority of 0)
+// broken by registration order. EmitSync guarantees this ordering; Emit
+// (being async) only guarantees it in the sense that higher-priority
+// handlers are hitting the worker pool's job queue first, not that they
+// finish first. When an emit matches several registered topics (e.g.
+// an exact topic plus "thing.*" and "**"), each matched topic's
+// handlers run as one group, and the groups are not merged by
+// priority: a root "**" handler runs before an exact-topic handler of
+// higher priority.
+
+// On registers a callback function for some topic. The returned
+// Subscription can be used to remove this callback specifically.
+func (e *Emitter) On(topic string, handler interface{}) Subscription {
+	return e.addHandler(false, 0, topic, handler)
 }
 
 // Once registers a callback function for some topic. The
 // callback is removed after it has been invoked.
-func (e *Emitter) Once(topic string, handler interface{}) {
-	e.addHandler(true, topic, handler)
+func (e *Emitter) Once(topic string, handler interface{}) Subscription {
+	return e.addHandler(true, 0, topic, handler)
+}
+
+// OnPriority registers a callback function for some topic, like On,
+// but runs it ahead of (or behind) other handlers on the same topic
+// according to priority - higher values run first.
+func (e *Emitter) OnPriority(topic string, priority int, handler interface{}) Subscription {
+	return e.addHandler(false, priority, topic, handler)
+}
+
+// Subscribe is a select-friendly alternative to On: instead of
+// invoking a callback, every matching emit's args are sent on the
+// returned channel as a []interface{}. buf sets the channel's
+// capacity; unlike On, Subscribe places no constraint on a topic's
+// argument signature (there's no callback to reflect over), so it
+// works the same on exact and wildcard topics alike.
+//
+// A slow consumer whose channel is full is handled by the emitter's
+// OverflowPolicy (see EmitterOptions), the same as a stalled handler
+// in the async worker pool - so it can block, drop the new event, drop
+// the oldest queued one, or panic, but it can never take the emitter
+// down with it. For Emit/EmitContext, a PolicyBlock delivery is queued
+// onto the worker pool like any other handler, so it blocks a worker
+// goroutine, never the emitting caller; EmitSync/EmitSyncContext
+// deliver (and so can block) on the caller's own goroutine, same as a
+// func handler would.
+//
+// Unsubscribe closes the channel and detaches it; a ranging consumer
+// sees this as the channel closing, just like any other Go channel.
+func (e *Emitter) Subscribe(topic string, buf int) (<-chan []interface{}, Subscription) {
+	path := splitTopic(topic)
+
+	e.mu.Lock()
+	node := e.root.descend(path)
+	handlers := node.handlers
+	if handlers == nil {
+		handlers = &Handlers{
+			funcs:    make([]HandlerFunc, 0),
+			wildcard: pathHasWildcard(path),
+		}
+		node.handlers = handlers
+	}
+
+	// Keep e.mu held until handlers.mu is locked too - a concurrent
+	// RemoveAllListeners(topic) takes e.mu to detach node.handlers,
+	// then handlers.mu to close the chans already on it. Releasing
+	// e.mu before locking handlers.mu here left a window where
+	// RemoveAllListeners could detach handlers out from under this
+	// call: the channel would be appended to an already-unreachable
+	// Handlers, so it would never be delivered to by Emit/EmitSync
+	// and never closed either, hanging a `for range ch` consumer
+	// forever. See addHandler for the same fix.
+	handlers.mu.Lock()
+	e.mu.Unlock()
+
+	id := atomic.AddUint64(&e.nextID, 1)
+	ch := make(chan []interface{}, buf)
+
+	handlers.chans = append(handlers.chans, &chanSub{id: id, ch: ch})
+	handlers.mu.Unlock()
+
+	return ch, Subscription{topic: topic, id: id, e: e}
+}
+
+/// REMOVING CALLBACKS:
+//
+// Off/RemoveAllListeners/ListenerCount let callers detach handlers
+// without having to hold on to the Subscription returned by On/Once.
+
+// Off removes every handler registered for topic that matches handler.
+// Since Go func values aren't comparable, named functions are matched
+// by comparing the underlying code pointer (reflect.ValueOf(fn).Pointer());
+// this means Off can't isolate one specific closure out of several
+// registrations of the same closure literal - use the Subscription
+// returned by On/Once for that.
+func (e *Emitter) Off(topic string, handler interface{}) {
+	target := reflect.ValueOf(handler).Pointer()
+
+	e.mu.Lock()
+	node := e.root.find(splitTopic(topic))
+	e.mu.Unlock()
+
+	if node == nil || node.handlers == nil {
+		return
+	}
+
+	handlers := node.handlers
+	handlers.mu.Lock()
+	defer handlers.mu.Unlock()
+
+	kept := make([]HandlerFunc, 0, len(handlers.funcs))
+	for _, hf := range handlers.funcs {
+		if hf.f.Pointer() != target {
+			kept = append(kept, hf)
+		}
+	}
+	handlers.funcs = kept
+}
+
+// RemoveAllListeners removes every handler registered for topic,
+// closing any channels handed out by Subscribe. Wildcard subscribers
+// on other topics that would otherwise match (e.g. "thing.*" when
+// topic is "thing.ready") are unaffected - this only clears handlers
+// registered on topic itself.
+func (e *Emitter) RemoveAllListeners(topic string) {
+	e.mu.Lock()
+	node := e.root.find(splitTopic(topic))
+	var handlers *Handlers
+	if node != nil {
+		handlers = node.handlers
+		node.handlers = nil
+	}
+	e.mu.Unlock()
+
+	if handlers == nil {
+		return
+	}
+
+	handlers.mu.Lock()
+	chans := handlers.chans
+	handlers.mu.Unlock()
+
+	// Closing happens outside handlers.mu: chanSub.close() can block
+	// behind an in-flight PolicyBlock send (see chanSub.close), and
+	// that shouldn't stall unrelated Subscribe/Off/emit calls on this
+	// topic while it waits.
+	for _, cs := range chans {
+		cs.close()
+	}
+}
+
+// ListenerCount returns the number of handlers and channel
+// subscribers registered exactly on topic. It does not count wildcard
+// subscribers that would also fire for topic.
+func (e *Emitter) ListenerCount(topic string) int {
+	e.mu.Lock()
+	node := e.root.find(splitTopic(topic))
+	e.mu.Unlock()
+
+	if node == nil || node.handlers == nil {
+		return 0
+	}
+
+	handlers := node.handlers
+	handlers.mu.Lock()
+	defer handlers.mu.Unlock()
+
+	return len(handlers.funcs) + len(handlers.chans)
+}
+
+func (e *Emitter) removeByID(topic string, id uint64) {
+	e.mu.Lock()
+	node := e.root.find(splitTopic(topic))
+	e.mu.Unlock()
+
+	if node == nil || node.handlers == nil {
+		return
+	}
+
+	handlers := node.handlers
+	handlers.mu.Lock()
+
+	for i, hf := range handlers.funcs {
+		if hf.id == id {
+			handlers.funcs = append(handlers.funcs[:i], handlers.funcs[i+1:]...)
+			handlers.mu.Unlock()
+			return
+		}
+	}
+
+	var removed *chanSub
+	for i, cs := range handlers.chans {
+		if cs.id == id {
+			removed = cs
+			handlers.chans = append(handlers.chans[:i], handlers.chans[i+1:]...)
+			break
+		}
+	}
+
+	handlers.mu.Unlock()
+
+	if removed != nil {
+		// See RemoveAllListeners: close outside handlers.mu so a
+		// blocked in-flight send can't stall other calls on this
+		// topic.
+		removed.close()
+	}
 }
 
 /// EMITTING EVENTS:
@@ -67,146 +395,366 @@ func (e *Emitter) Once(topic string, handler interface{}) {
 // Emit/EmitAsync call all registered callbacks for a topic,
 // passing in args as input parameters.
 //
-// Callbacks are fired in the order they were registered.
+// Callbacks registered on the same topic string are fired in priority
+// order (see OnPriority), with ties broken by registration order;
+// callbacks matched through different topic patterns run group by
+// group, not merged by priority (see On above). EmitSync guarantees
+// this ordering exactly, since it calls one handler at a time, in this
+// order, before moving to the next.
 //
 // Requirements:
 // 1. The types and positions of args must exactly match the
-//    types and positions of the callback's input parameters.
-
-// Emit fires callbacks asynchronously, spawning a goroutine
-// for each callback.
+//    types and positions of an exact-topic callback's input
+//    parameters. Wildcard callbacks whose declared signature doesn't
+//    match args are silently skipped for this emit rather than
+//    panicking, since a single wildcard (especially "**") is expected
+//    to observe emits of many different shapes. The one exception is
+//    a wildcard handler declared as func(topic string, args
+//    ...interface{}) - it receives the topic name plus the raw args
+//    for every matching emit, regardless of their shape.
+
+// Emit fires callbacks asynchronously, queuing each callback as a job
+// for the bounded worker pool instead of spawning a goroutine per
+// callback - see EmitterOptions in dispatch.go for the pool size,
+// queue capacity, and overflow policy (PolicyBlock by default, which
+// can apply backpressure to the calling goroutine if the queue is
+// full).
 func (e *Emitter) Emit(topic string, args ...interface{}) {
-	e.callHandlers(false, topic, args...)
+	e.emit(false, nil, topic, args...)
 }
 
 // EmitSync fires callbacks synchronously, waiting for each
 // callback to return before firing the next one.
 func (e *Emitter) EmitSync(topic string, args ...interface{}) {
-	e.callHandlers(true, topic, args...)
+	e.emit(true, nil, topic, args...)
 }
 
-func (e *Emitter) addHandler(doOnce bool, topic string, handler interface{}) {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
+func (e *Emitter) addHandler(doOnce bool, priority int, topic string, handler interface{}) Subscription {
 	cbType := reflect.TypeOf(handler)
 	if cbType.Kind() != reflect.Func {
 		panicF("Expected handler to have kind Func, got: %s", cbType.Kind())
 	}
 
-	// fmt.Printf("T[%s] handler func has NumIn: %d\n", topic, cbType.NumIn())
-
-	handlers, exists := e.listeners[topic]
+	path := splitTopic(topic)
+	isWildcard := pathHasWildcard(path)
 
-	// No handlers yet assigned to this topic
-	if !exists {
-		inTypes := make([]reflect.Type, cbType.NumIn())
+	inTypes := make([]reflect.Type, cbType.NumIn())
+	for i := range inTypes {
+		inTypes[i] = cbType.In(i)
+	}
 
-		// Add the input types for the handler function
-		for i := 0; i < cbType.NumIn(); i++ {
-			inTypes[i] = cbType.In(i)
-		}
+	// e.mu only guards the trie (finding/creating the node); the
+	// Handlers it points to has its own mu, same as every other
+	// method that reads or mutates handlers.funcs (emit, grabFuncs,
+	// Off, removeByID, RemoveAllListeners).
+	e.mu.Lock()
+	node := e.root.descend(path)
+	handlers := node.handlers
 
+	// No handlers yet assigned to this topic - this can also happen
+	// if Subscribe got here first, in which case handlers exists but
+	// sigSet is still false.
+	if handlers == nil {
 		handlers = &Handlers{
-			in:    inTypes,
-			funcs: make([]HandlerFunc, 0),
+			funcs:    make([]HandlerFunc, 0),
+			wildcard: isWildcard,
 		}
+		node.handlers = handlers
+	}
 
-		e.listeners[topic] = handlers
-	} else {
-		// We have previous handlers assigned to this topic.
-		// Make sure the new handler matches the inTypes:
-		numIn := cbType.NumIn()
-		if numIn != len(handlers.in) {
-			panicF("T[%s] new handler wrong argument count. Expected %d; got %d", topic, len(handlers.in), numIn)
-		}
+	// Keep e.mu held until handlers.mu is locked too, rather than
+	// releasing e.mu first - a concurrent RemoveAllListeners(topic)
+	// takes e.mu to detach node.handlers before taking handlers.mu
+	// itself, and releasing e.mu here first left a window where it
+	// could detach handlers out from under this call. The handler
+	// would then be appended to an already-unreachable Handlers:
+	// silently never fired by Emit/EmitSync, and ListenerCount would
+	// report it as gone despite On's caller holding what looks like a
+	// live Subscription.
+	handlers.mu.Lock()
+	e.mu.Unlock()
+	defer handlers.mu.Unlock()
+
+	if !isWildcard {
+		if !handlers.sigSet {
+			// First exact-topic func handler: this registration fixes
+			// the topic's signature for every subsequent On/Once/
+			// OnPriority call.
+			handlers.in = inTypes
+			handlers.wantsContext = len(inTypes) > 0 && inTypes[0] == ctxType
+			handlers.sigSet = true
+		} else {
+			// We have a previous func handler assigned to this exact
+			// topic. Make sure the new handler matches the inTypes:
+			numIn := cbType.NumIn()
+			if numIn != len(handlers.in) {
+				panicF("T[%s] new handler wrong argument count. Expected %d; got %d", topic, len(handlers.in), numIn)
+			}
 
-		for i := 0; i < cbType.NumIn(); i++ {
-			if cbType.In(i).Kind() != handlers.in[i].Kind() {
-				panicF("T[%s] new handler invalid argument at position %d. Expected %s; got %s", topic, i, handlers.in[i].Kind(), cbType.In(i).Kind())
+			for i := 0; i < numIn; i++ {
+				if cbType.In(i).Kind() != handlers.in[i].Kind() {
+					panicF("T[%s] new handler invalid argument at position %d. Expected %s; got %s", topic, i, handlers.in[i].Kind(), cbType.In(i).Kind())
+				}
 			}
 		}
 	}
+	// Wildcard topics don't enforce a shared signature - each handler
+	// is matched against the emitted args independently when it fires.
+
+	id := atomic.AddUint64(&e.nextID, 1)
 
-	// Add the handler to the topic:
+	// Add the handler to the topic, then re-sort so funcs stays
+	// ordered by (-priority, id) - highest priority first, ties
+	// broken by registration order.
 	handlers.funcs = append(handlers.funcs, HandlerFunc{
-		once: doOnce,
-		f:    reflect.ValueOf(handler),
+		id:       id,
+		priority: priority,
+		once:     doOnce,
+		f:        reflect.ValueOf(handler),
+		in:       inTypes,
+		variadic: cbType.IsVariadic(),
 	})
+	handlers.sortByPriority()
+
+	return Subscription{topic: topic, id: id, e: e}
 }
 
-func (e *Emitter) callHandlers(doSync bool, topic string, args ...interface{}) {
+// sortByPriority restores h.funcs to its canonical order: highest
+// priority first, ties broken by ascending registration order (id).
+func (h *Handlers) sortByPriority() {
+	sort.Slice(h.funcs, func(i, j int) bool {
+		if h.funcs[i].priority != h.funcs[j].priority {
+			return h.funcs[i].priority > h.funcs[j].priority
+		}
+		return h.funcs[i].id < h.funcs[j].id
+	})
+}
+
+// emit is the shared implementation behind Emit/EmitSync and their
+// context-aware counterparts. ctx is nil unless called via
+// EmitContext/EmitSyncContext.
+func (e *Emitter) emit(doSync bool, ctx context.Context, topic string, args ...interface{}) error {
+	path := splitTopic(topic)
+
 	e.mu.Lock()
-	// Emitting to no listeners! Do nothing.
-	if _, exists := e.listeners[topic]; !exists {
+	var matched []*Handlers
+	e.root.collect(path, &matched)
+	e.mu.Unlock()
+
+	if len(matched) == 0 {
 		fmt.Printf("T[%s] has no listeners to emit to", topic)
-		e.mu.Unlock()
-		return
+		return nil
 	}
-	handlers := e.listeners[topic]
-	e.mu.Unlock()
-	handlers.mu.Lock()
 
-	// fmt.Printf("T[%s] emitting with %d args\n", topic, len(args))
+	var invs []invocation
+	var chanSends []chanSend
+
+	for _, handlers := range matched {
+		handlers.mu.Lock()
+
+		if handlers.wildcard {
+			invs = append(invs, handlers.grabMatching(topic, ctx, args)...)
+		} else if len(handlers.funcs) > 0 {
+			// Exact-topic handlers: preserve the original, strict
+			// contract - every arg must match the topic's declared
+			// signature, or the whole emit panics. A topic with only
+			// Subscribe channels and no func handlers has no signature
+			// to enforce, so this is skipped entirely in that case.
+			inArgs := buildExactArgs(topic, handlers, ctx, args)
 
-	// Construct input args and make sure the types in args match
-	// the types specified by the handlers:
-	inArgs := make([]reflect.Value, len(args))
+			for _, hf := range handlers.grabFuncs() {
+				invs = append(invs, invocation{f: hf.f, args: inArgs})
+			}
+		}
 
-	if len(args) != len(handlers.in) {
-		panicF("T[%s] has %d input args; got %d", topic, len(handlers.in), len(args))
+		for _, cs := range handlers.chans {
+			chanSends = append(chanSends, chanSend{sub: cs, args: args})
+		}
+
+		handlers.mu.Unlock()
+	}
+
+	if doSync {
+		// EmitSync/EmitSyncContext deliver on the caller's own
+		// goroutine, same as a func handler - but still ctx-aware, so
+		// an already-cancelled EmitSyncContext doesn't block on a full
+		// channel under PolicyBlock.
+		for _, cs := range chanSends {
+			e.sendToChan(ctx, topic, cs.sub, cs.args)
+		}
+
+		return e.runSync(ctx, topic, invs)
+	}
+
+	// Emit/EmitContext queue channel deliveries onto the worker pool
+	// exactly like handler invocations, so a full/slow consumer
+	// channel can only ever block a worker goroutine, never the
+	// emitting caller - preserving Emit's documented non-blocking
+	// contract.
+	e.dispatchChansAsync(ctx, topic, chanSends)
+	e.dispatchAsync(ctx, topic, invs)
+	return nil
+}
+
+// buildExactArgs validates args against an exact topic's declared
+// signature, prepending ctx when the handlers expect one, and panics
+// (as Emit/EmitSync have always done) on any mismatch.
+func buildExactArgs(topic string, handlers *Handlers, ctx context.Context, args []interface{}) []reflect.Value {
+	expected := handlers.in
+	offset := 0
+
+	if handlers.wantsContext {
+		if ctx == nil {
+			panicF("T[%s] handlers expect a context.Context; use EmitContext/EmitSyncContext", topic)
+		}
+		expected = handlers.in[1:]
+		offset = 1
+	}
+
+	if len(args) != len(expected) {
+		panicF("T[%s] has %d input args; got %d", topic, len(expected), len(args))
+	}
+
+	inArgs := make([]reflect.Value, len(expected)+offset)
+	if offset == 1 {
+		inArgs[0] = reflect.ValueOf(ctx)
 	}
 
 	for i, arg := range args {
 		t := reflect.TypeOf(arg)
-		if t.Kind() != handlers.in[i].Kind() {
-			panicF("T[%s] invalid argument at position %d. Expected %s; got %s", topic, i, handlers.in[i].Kind(), t.Kind())
+		if t.Kind() != expected[i].Kind() {
+			panicF("T[%s] invalid argument at position %d. Expected %s; got %s", topic, i, expected[i].Kind(), t.Kind())
 		}
+		inArgs[i+offset] = reflect.ValueOf(arg)
+	}
+
+	return inArgs
+}
+
+// grabFuncs gathers all handlers registered on an exact topic,
+// removing any registered with Once.
+func (h *Handlers) grabFuncs() []HandlerFunc {
+	funcs := make([]HandlerFunc, len(h.funcs))
+	copy(funcs, h.funcs)
 
-		inArgs[i] = reflect.ValueOf(arg)
+	kept := make([]HandlerFunc, 0, len(h.funcs))
+	for _, hf := range h.funcs {
+		if !hf.once {
+			kept = append(kept, hf)
+		}
 	}
+	h.funcs = kept
 
-	// Gather the handlers to be called, removing any that are registered as "once":
-	funcs := handlers.grabFuncs()
+	return funcs
+}
 
-	// Unlock mutex - we're done mutating this object, and the callbacks
-	// may need to use it.
-	handlers.mu.Unlock()
+// grabMatching gathers the invocations for handlers on a wildcard
+// topic whose declared signature fits this emit. A handler whose
+// signature doesn't fit is left registered - it's simply skipped for
+// this particular emit, not removed. ctx is nil unless this emit came
+// from EmitContext/EmitSyncContext.
+func (h *Handlers) grabMatching(topic string, ctx context.Context, args []interface{}) []invocation {
+	var invs []invocation
+	kept := make([]HandlerFunc, 0, len(h.funcs))
+
+	for _, hf := range h.funcs {
+		inv, ok := hf.buildInvocation(topic, ctx, args)
+		if !ok {
+			kept = append(kept, hf)
+			continue
+		}
 
-	// Call each function with the input args
-	if doSync {
-		callFuncsSync(funcs, inArgs)
-	} else {
-		callFuncsAsync(funcs, inArgs)
+		invs = append(invs, inv)
+		if !hf.once {
+			kept = append(kept, hf)
+		}
 	}
+
+	h.funcs = kept
+	return invs
 }
 
-func (h *Handlers) grabFuncs() []reflect.Value {
-	funcs := make([]reflect.Value, len(h.funcs))
-	notRemoved := make([]HandlerFunc, 0)
+// buildInvocation tries to build the call for hf given an emit's
+// topic, (optional) context, and args, returning false if hf's
+// declared signature doesn't fit any calling convention a wildcard
+// handler may use.
+func (hf HandlerFunc) buildInvocation(topic string, ctx context.Context, args []interface{}) (invocation, bool) {
+	if ctx != nil && len(hf.in) > 0 && hf.in[0] == ctxType {
+		if inArgs, ok := matchArgs(hf.in[1:], args); ok {
+			full := make([]reflect.Value, 0, len(inArgs)+1)
+			full = append(full, reflect.ValueOf(ctx))
+			full = append(full, inArgs...)
+			return invocation{f: hf.f, args: full}, true
+		}
+	}
+
+	if inArgs, ok := matchArgs(hf.in, args); ok {
+		return invocation{f: hf.f, args: inArgs}, true
+	}
+
+	// A handler declared as func(topic string, args ...interface{})
+	// can observe every matching emit regardless of its shape - e.g.
+	// a "**" audit log.
+	if hf.variadic && len(hf.in) == 2 && hf.in[0].Kind() == reflect.String &&
+		hf.in[1] == reflect.TypeOf([]interface{}(nil)) {
+		return invocation{
+			f:     hf.f,
+			args:  []reflect.Value{reflect.ValueOf(topic), reflect.ValueOf(args)},
+			slice: true,
+		}, true
+	}
 
-	for i := 0; i < len(h.funcs); i++ {
-		funcs[i] = h.funcs[i].f
+	return invocation{}, false
+}
+
+// matchArgs reports whether args can be passed to a handler whose
+// declared parameter types are in, returning the built reflect.Values
+// if so. This compares actual types (via AssignableTo, so an arg
+// satisfying a handler's interface parameter still matches), not
+// Kind - two distinct struct or interface types share a Kind but
+// are not interchangeable, and a wildcard handler is meant to be
+// skipped for an emit its signature doesn't fit, not handed a value
+// it can't actually accept.
+func matchArgs(in []reflect.Type, args []interface{}) ([]reflect.Value, bool) {
+	if len(in) != len(args) {
+		return nil, false
+	}
 
-		// Remove callback now that we're firing it
-		if !h.funcs[i].once {
-			notRemoved = append(notRemoved, h.funcs[i])
+	out := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		v := reflect.ValueOf(arg)
+		// A nil arg has no type to compare, so it fits no declared
+		// parameter here.
+		if !v.IsValid() || !v.Type().AssignableTo(in[i]) {
+			return nil, false
 		}
+		out[i] = v
 	}
 
-	h.funcs = notRemoved
-	return funcs
+	return out, true
 }
 
-func callFuncsSync(funcs []reflect.Value, inArgs []reflect.Value) {
-	for _, f := range funcs {
-		f.Call(inArgs)
+// invocation is a single handler call queued up by an emit. slice
+// controls whether it's placed with Call (args line up with the
+// handler's declared parameters) or CallSlice (the last arg is a
+// slice to be spread across the handler's variadic parameter).
+type invocation struct {
+	f     reflect.Value
+	args  []reflect.Value
+	slice bool
+}
+
+func (inv invocation) call() {
+	if inv.slice {
+		inv.f.CallSlice(inv.args)
+	} else {
+		inv.f.Call(inv.args)
 	}
 }
 
-func callFuncsAsync(funcs []reflect.Value, inArgs []reflect.Value) {
-	for _, f := range funcs {
-		go f.Call(inArgs)
+func callInvocationsSync(invs []invocation) {
+	for _, inv := range invs {
+		inv.call()
 	}
 }
 
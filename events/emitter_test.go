@@ -0,0 +1,226 @@
+package events_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wadeAlexC/go-events/events"
+)
+
+// TestOnPriorityOrdering verifies that EmitSync fires handlers in
+// priority order (highest first), with ties broken by registration
+// order - the contract OnPriority documents.
+func TestOnPriorityOrdering(t *testing.T) {
+	e := events.NewEmitter()
+
+	var mu sync.Mutex
+	var fired []string
+
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			fired = append(fired, name)
+			mu.Unlock()
+		}
+	}
+
+	e.On("t", record("default-a"))
+	e.OnPriority("t", 5, record("high"))
+	e.On("t", record("default-b"))
+	e.OnPriority("t", -5, record("low"))
+	e.OnPriority("t", 5, record("high-2"))
+
+	e.EmitSync("t")
+
+	want := []string{"high", "high-2", "default-a", "default-b", "low"}
+	mu.Lock()
+	got := append([]string(nil), fired...)
+	mu.Unlock()
+
+	if len(got) != len(want) {
+		t.Fatalf("fired order = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("fired order = %v, want %v", got, want)
+		}
+	}
+}
+
+// TestWildcardSkipsMismatchedShape verifies that a wildcard handler
+// whose declared signature doesn't fit an emit is silently skipped
+// for that emit (and stays registered), rather than panicking the way
+// an exact-topic handler mismatch does.
+func TestWildcardSkipsMismatchedShape(t *testing.T) {
+	e := events.NewEmitter()
+
+	var mu sync.Mutex
+	var stringFires, intFires int
+
+	e.On("thing.*", func(name string) {
+		mu.Lock()
+		stringFires++
+		mu.Unlock()
+	})
+	e.On("thing.*", func(val int) {
+		mu.Lock()
+		intFires++
+		mu.Unlock()
+	})
+
+	e.EmitSync("thing.ready", "alex")
+	e.EmitSync("thing.valued", 5)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if stringFires != 1 {
+		t.Fatalf("string handler fired %d times, want 1", stringFires)
+	}
+	if intFires != 1 {
+		t.Fatalf("int handler fired %d times, want 1", intFires)
+	}
+}
+
+// TestWildcardSkipsMismatchedType is a regression test: matchArgs used
+// to compare reflect.Type.Kind() instead of the actual type, so two
+// distinct struct types sharing a Kind (both reflect.Struct) were
+// treated as a match - panicking a wildcard handler with the wrong
+// struct instead of skipping it, as TestWildcardSkipsMismatchedShape
+// already requires for mismatched shapes.
+func TestWildcardSkipsMismatchedType(t *testing.T) {
+	type EventA struct{ A int }
+	type EventB struct{ B string }
+
+	e := events.NewEmitter()
+
+	var mu sync.Mutex
+	var aFires int
+
+	e.On("thing.*", func(a EventA) {
+		mu.Lock()
+		aFires++
+		mu.Unlock()
+	})
+
+	e.EmitSync("thing.ready", EventB{B: "nope"})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if aFires != 0 {
+		t.Fatalf("EventA handler fired %d times on an EventB emit, want 0", aFires)
+	}
+}
+
+// TestConcurrentOnAndEmit is a regression test for a data race where
+// addHandler mutated Handlers.funcs (and re-sorted it) while holding
+// only Emitter.mu, racing with Emit/EmitSync mutating the same slice
+// under Handlers.mu. Run with -race to catch a regression.
+func TestConcurrentOnAndEmit(t *testing.T) {
+	e := events.NewEmitter()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+				e.OnPriority("t", i%3, func() {})
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				e.EmitSync("t")
+			}
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	close(done)
+	wg.Wait()
+}
+
+// TestConcurrentRegisterAndRemoveAllListeners is a regression test:
+// addHandler/Subscribe used to release e.mu before locking
+// handlers.mu, leaving a window where a concurrent
+// RemoveAllListeners(topic) could detach node.handlers in between -
+// so the handler/channel being registered got appended to an
+// already-unreachable Handlers instead of the one actually linked
+// into the trie. For On this meant a handler silently never fired
+// despite its Subscription looking live; for Subscribe, the returned
+// channel was never delivered to *and never closed*, hanging a
+// `for range ch` consumer forever.
+//
+// This isn't a data race (both sides always hold their own lock when
+// mutating shared state) - it's a lost-update race, so -race can't
+// catch it directly, and the window is so narrow that registering and
+// removing one topic at a time essentially never lands in it. Instead
+// this fires registration and removal for many fresh topics all at
+// once, relying on real contention on Emitter.mu across goroutines to
+// force enough interleaving to land in the window, then relies on one
+// invariant that must hold no matter how registration and removal
+// interleaved: a second, deterministic RemoveAllListeners after the
+// race settles always closes a Subscribe channel that's still
+// reachable from the trie. If the channel was instead orphaned by the
+// bug above, this second call can't reach it and the channel never
+// closes.
+func TestConcurrentRegisterAndRemoveAllListeners(t *testing.T) {
+	e := events.NewEmitter()
+
+	const iterations = 20000
+	chans := make([]<-chan []interface{}, iterations)
+
+	var wg sync.WaitGroup
+	wg.Add(iterations * 2)
+	for i := 0; i < iterations; i++ {
+		i := i
+		topic := fmt.Sprintf("race.%d", i)
+
+		go func() {
+			defer wg.Done()
+			e.On(topic, func() {})
+			chans[i], _ = e.Subscribe(topic, 1)
+		}()
+		go func() {
+			defer wg.Done()
+			e.RemoveAllListeners(topic)
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < iterations; i++ {
+		topic := fmt.Sprintf("race.%d", i)
+
+		// Whichever side of the race won above, this cleans up a
+		// registration that happened after the first
+		// RemoveAllListeners already ran - a legitimate, non-buggy
+		// outcome where the channel is still open and should be.
+		e.RemoveAllListeners(topic)
+
+		ch := chans[i]
+		drained := make(chan struct{})
+		go func() {
+			for range ch {
+			}
+			close(drained)
+		}()
+
+		select {
+		case <-drained:
+		case <-time.After(time.Second):
+			t.Fatalf("iteration %d: Subscribe channel was never closed - orphaned by a racing RemoveAllListeners", i)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/wadeAlexC/go-events/events"
+)
+
+// TestSubscriptionUnsubscribe verifies that Unsubscribe detaches only
+// the handler it was issued for, leaving other handlers on the same
+// topic (including other registrations of the same closure literal)
+// untouched.
+func TestSubscriptionUnsubscribe(t *testing.T) {
+	e := events.NewEmitter()
+
+	fires := 0
+	handler := func() { fires++ }
+
+	sub := e.On("t", handler)
+	e.On("t", handler) // a second registration of the same closure
+
+	sub.Unsubscribe()
+	e.EmitSync("t")
+
+	if fires != 1 {
+		t.Fatalf("fires = %d, want 1 (only the un-unsubscribed registration)", fires)
+	}
+	if got := e.ListenerCount("t"); got != 1 {
+		t.Fatalf("ListenerCount(t) = %d, want 1", got)
+	}
+}
+
+// TestOffRemovesAllMatchingHandlers verifies that Off removes every
+// handler registered for topic whose code pointer matches handler,
+// even if it was registered more than once.
+func TestOffRemovesAllMatchingHandlers(t *testing.T) {
+	e := events.NewEmitter()
+
+	fires := 0
+	handler := func() { fires++ }
+
+	e.On("t", handler)
+	e.On("t", handler)
+	other := 0
+	e.On("t", func() { other++ })
+
+	e.Off("t", handler)
+	e.EmitSync("t")
+
+	if fires != 0 {
+		t.Fatalf("fires = %d, want 0 after Off", fires)
+	}
+	if other != 1 {
+		t.Fatalf("other = %d, want 1 (unaffected by Off)", other)
+	}
+	if got := e.ListenerCount("t"); got != 1 {
+		t.Fatalf("ListenerCount(t) = %d, want 1", got)
+	}
+}
+
+// TestRemoveAllListenersClosesChannelsAndClearsHandlers verifies that
+// RemoveAllListeners detaches every func handler and Subscribe channel
+// registered exactly on topic, closing each channel, while leaving a
+// wildcard subscriber on a different topic node unaffected.
+func TestRemoveAllListenersClosesChannelsAndClearsHandlers(t *testing.T) {
+	e := events.NewEmitter()
+
+	fires := 0
+	e.On("thing.ready", func() { fires++ })
+	ch, _ := e.Subscribe("thing.ready", 1)
+
+	wildcardFires := 0
+	e.On("thing.*", func() { wildcardFires++ })
+
+	e.RemoveAllListeners("thing.ready")
+
+	if _, ok := <-ch; ok {
+		t.Fatal("Subscribe channel still open after RemoveAllListeners")
+	}
+	if got := e.ListenerCount("thing.ready"); got != 0 {
+		t.Fatalf("ListenerCount(thing.ready) = %d, want 0", got)
+	}
+
+	e.EmitSync("thing.ready")
+	if fires != 0 {
+		t.Fatalf("fires = %d, want 0 after RemoveAllListeners", fires)
+	}
+	if wildcardFires != 1 {
+		t.Fatalf("wildcardFires = %d, want 1 (unaffected by RemoveAllListeners on the exact topic)", wildcardFires)
+	}
+}
+
+// TestListenerCountCountsFuncsAndChans verifies ListenerCount reflects
+// both func handlers and Subscribe channels registered exactly on
+// topic, and doesn't count wildcard subscribers that would also match.
+func TestListenerCountCountsFuncsAndChans(t *testing.T) {
+	e := events.NewEmitter()
+
+	if got := e.ListenerCount("t"); got != 0 {
+		t.Fatalf("ListenerCount(t) = %d, want 0 on an unused topic", got)
+	}
+
+	e.On("t", func() {})
+	_, _ = e.Subscribe("t", 0)
+	e.On("t.*", func() {})
+
+	if got := e.ListenerCount("t"); got != 2 {
+		t.Fatalf("ListenerCount(t) = %d, want 2 (one func handler + one channel)", got)
+	}
+}
@@ -0,0 +1,106 @@
+package events_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wadeAlexC/go-events/events"
+)
+
+// TestSubscribeDoesNotBlockEmit is a regression test: Subscribe
+// channel sends used to happen synchronously on the emitting
+// goroutine for every emit path, including the async Emit/EmitContext,
+// so one undrained channel under the default PolicyBlock could stall
+// Emit forever - contradicting Emit's documented non-blocking
+// contract. A full channel must only ever block a worker, not the
+// caller.
+func TestSubscribeDoesNotBlockEmit(t *testing.T) {
+	e := events.NewEmitter()
+
+	ch, _ := e.Subscribe("t", 1)
+
+	// Fill the channel's buffer so the next delivery would have to
+	// wait for a receiver under PolicyBlock.
+	e.EmitSync("t")
+
+	done := make(chan struct{})
+	go func() {
+		e.Emit("t")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Emit blocked on a full Subscribe channel")
+	}
+
+	<-ch
+}
+
+// TestSubscribeEmitSyncContextRespectsCancellation is a regression
+// test: sendToChan used to ignore ctx entirely, so an already-
+// cancelled EmitSyncContext would still block forever on a full
+// channel under PolicyBlock instead of returning promptly like it
+// does for func handlers.
+func TestSubscribeEmitSyncContextRespectsCancellation(t *testing.T) {
+	e := events.NewEmitter()
+
+	ch, _ := e.Subscribe("t", 1)
+	e.EmitSync("t") // fill the one-slot buffer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- e.EmitSyncContext(ctx, "t")
+	}()
+
+	select {
+	case <-done:
+		// No func handlers are registered on this topic, so there's
+		// nothing for runSync to abandon - the point of this test is
+		// that the channel send itself gave up on ctx instead of
+		// blocking forever.
+	case <-time.After(time.Second):
+		t.Fatal("EmitSyncContext blocked on a full Subscribe channel past cancellation")
+	}
+
+	<-ch
+}
+
+// TestSubscribeConcurrentCloseDoesNotPanic is a regression test: emit
+// grabs a Subscribe channel under handlers.mu, then releases the lock
+// before actually sending on it (synchronously for EmitSync, or later
+// from a worker for Emit). A concurrent Unsubscribe/RemoveAllListeners
+// can close that same channel in the gap, and a send on a closed
+// channel panics the sender instead of the intended subscriber.
+func TestSubscribeConcurrentCloseDoesNotPanic(t *testing.T) {
+	e := events.NewEmitter()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		ch, sub := e.Subscribe("t", 0)
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			e.EmitSync("t")
+		}()
+		go func() {
+			defer wg.Done()
+			sub.Unsubscribe()
+		}()
+
+		// Drain whatever made it through before the close; a panic
+		// from the emitting goroutine above would fail the test
+		// regardless of what's read here.
+		for range ch {
+		}
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,104 @@
+package events
+
+import "strings"
+
+const (
+	// wildcardOne matches exactly one topic segment, e.g. "thing.*"
+	// matches "thing.ready" and "thing.valued" but not "thing.a.b".
+	wildcardOne = "*"
+	// wildcardAll matches the rest of the topic, including zero
+	// remaining segments, e.g. "**" matches every topic and
+	// "thing.**" matches "thing", "thing.ready", "thing.a.b", etc.
+	wildcardAll = "**"
+)
+
+// splitTopic breaks a topic string into its hierarchical segments.
+// Segments may be delimited with '.' or '/' - "thing.ready" and
+// "thing/ready" both split into ["thing", "ready"].
+func splitTopic(topic string) []string {
+	return strings.FieldsFunc(topic, func(r rune) bool {
+		return r == '.' || r == '/'
+	})
+}
+
+// pathHasWildcard reports whether any segment of path is a wildcard.
+func pathHasWildcard(path []string) bool {
+	for _, seg := range path {
+		if seg == wildcardOne || seg == wildcardAll {
+			return true
+		}
+	}
+	return false
+}
+
+// topicNode is a single node in the emitter's topic trie. Each
+// segment of a registered topic - literal, "*", or "**" - walks to
+// (creating if necessary) one child node. handlers, if non-nil, holds
+// the callbacks registered exactly at this node's path.
+type topicNode struct {
+	handlers *Handlers
+	children map[string]*topicNode
+}
+
+func newTopicNode() *topicNode {
+	return &topicNode{children: make(map[string]*topicNode)}
+}
+
+// descend walks path from n, creating child nodes as needed, and
+// returns the node at the end of the path.
+func (n *topicNode) descend(path []string) *topicNode {
+	node := n
+	for _, seg := range path {
+		child, ok := node.children[seg]
+		if !ok {
+			child = newTopicNode()
+			node.children[seg] = child
+		}
+		node = child
+	}
+	return node
+}
+
+// find walks path from n without creating anything, returning nil if
+// the path doesn't exist.
+func (n *topicNode) find(path []string) *topicNode {
+	node := n
+	for _, seg := range path {
+		child, ok := node.children[seg]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+	return node
+}
+
+// collect gathers every *Handlers that matches an emit on path,
+// fanning out to "*" (matches exactly one remaining segment) and "**"
+// (matches the rest of path, including zero remaining segments)
+// children in addition to an exact literal match.
+func (n *topicNode) collect(path []string, out *[]*Handlers) {
+	if n == nil {
+		return
+	}
+
+	if all, ok := n.children[wildcardAll]; ok && all.handlers != nil {
+		*out = append(*out, all.handlers)
+	}
+
+	if len(path) == 0 {
+		if n.handlers != nil {
+			*out = append(*out, n.handlers)
+		}
+		return
+	}
+
+	seg, rest := path[0], path[1:]
+
+	if child, ok := n.children[seg]; ok {
+		child.collect(rest, out)
+	}
+	if one, ok := n.children[wildcardOne]; ok {
+		one.collect(rest, out)
+	}
+}
@@ -0,0 +1,204 @@
+// Package typed provides a generics-based alternative to events.Emitter.
+//
+// events.Emitter resolves a handler's signature with reflection every
+// time it is registered or fired (~150+ ns/op per handler), and any
+// mismatch only surfaces as a runtime panic. Topic[T] and Bus instead
+// push that work onto the compiler: Subscribe only accepts a func(T),
+// and Emit/EmitSync only accept a T, so there is no reflect.Call on
+// the hot path and no mismatched-handler panics to guard against.
+package typed
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Subscription is returned by Subscribe/SubscribeOnce and can be used
+// to detach that specific handler.
+type Subscription struct {
+	unsubscribe func()
+}
+
+// Unsubscribe removes the handler this Subscription was issued for.
+// It is a no-op if the handler has already been removed, e.g. because
+// it was registered with SubscribeOnce and has already fired.
+func (s Subscription) Unsubscribe() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+}
+
+// Topic is a type-safe, single-event-type pub/sub channel.
+type Topic[T any] struct {
+	mu       sync.Mutex
+	nextID   uint64
+	handlers []typedHandler[T]
+}
+
+type typedHandler[T any] struct {
+	id   uint64
+	once bool
+	f    func(T)
+}
+
+// NewTopic creates an empty Topic for events of type T.
+func NewTopic[T any]() *Topic[T] {
+	return &Topic[T]{}
+}
+
+// Subscribe registers a handler that is called every time Emit or
+// EmitSync is called on this topic.
+func (t *Topic[T]) Subscribe(handler func(T)) Subscription {
+	return t.subscribe(false, handler)
+}
+
+// SubscribeOnce registers a handler that is removed after it fires once.
+func (t *Topic[T]) SubscribeOnce(handler func(T)) Subscription {
+	return t.subscribe(true, handler)
+}
+
+// SubscribeChan is a select-friendly alternative to Subscribe: instead
+// of invoking a callback, every value passed to Emit/EmitSync is sent
+// on the returned channel. buf sets the channel's capacity.
+//
+// Topic has no worker pool or OverflowPolicy to fall back on the way
+// events.Emitter.Subscribe does, so a full channel simply drops the
+// value rather than blocking the emitting goroutine (or, for EmitSync,
+// the caller) forever.
+//
+// Unsubscribe closes the channel and detaches it.
+func (t *Topic[T]) SubscribeChan(buf int) (<-chan T, Subscription) {
+	ch := make(chan T, buf)
+	sub := t.Subscribe(func(v T) {
+		select {
+		case ch <- v:
+		default:
+		}
+	})
+
+	var closeOnce sync.Once
+	return ch, Subscription{unsubscribe: func() {
+		sub.Unsubscribe()
+		closeOnce.Do(func() { close(ch) })
+	}}
+}
+
+func (t *Topic[T]) subscribe(once bool, handler func(T)) Subscription {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := atomic.AddUint64(&t.nextID, 1)
+	t.handlers = append(t.handlers, typedHandler[T]{id: id, once: once, f: handler})
+
+	return Subscription{unsubscribe: func() { t.remove(id) }}
+}
+
+func (t *Topic[T]) remove(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, h := range t.handlers {
+		if h.id == id {
+			t.handlers = append(t.handlers[:i], t.handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Emit fires every registered handler asynchronously, spawning a
+// goroutine for each one.
+func (t *Topic[T]) Emit(val T) {
+	for _, f := range t.grabHandlers() {
+		go f(val)
+	}
+}
+
+// EmitSync fires every registered handler synchronously, in
+// registration order, waiting for each to return before calling the next.
+func (t *Topic[T]) EmitSync(val T) {
+	for _, f := range t.grabHandlers() {
+		f(val)
+	}
+}
+
+// grabHandlers returns the funcs to call for this emit and drops any
+// "once" handlers from the topic now that they're about to fire.
+func (t *Topic[T]) grabHandlers() []func(T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	funcs := make([]func(T), len(t.handlers))
+	kept := make([]typedHandler[T], 0, len(t.handlers))
+
+	for i, h := range t.handlers {
+		funcs[i] = h.f
+		if !h.once {
+			kept = append(kept, h)
+		}
+	}
+
+	t.handlers = kept
+	return funcs
+}
+
+// Bus multiplexes many event types over a single registry, keyed by
+// the reflect.Type of each event's Go type - similar to libp2p's
+// basicBus. Go doesn't allow a method to introduce new type
+// parameters, so the bus is driven by the package-level Subscribe/
+// SubscribeOnce/Emit/EmitSync functions below rather than Bus methods.
+type Bus struct {
+	mu     sync.Mutex
+	topics map[reflect.Type]interface{}
+}
+
+// NewBus creates an empty Bus.
+func NewBus() *Bus {
+	return &Bus{topics: make(map[reflect.Type]interface{})}
+}
+
+// topicFor returns the Topic[T] registered on b, creating one if this
+// is the first time T has been used with b.
+func topicFor[T any](b *Bus) *Topic[T] {
+	var zero *T
+	key := reflect.TypeOf(zero).Elem()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing, ok := b.topics[key]
+	if !ok {
+		topic := NewTopic[T]()
+		b.topics[key] = topic
+		return topic
+	}
+
+	return existing.(*Topic[T])
+}
+
+// Subscribe registers a handler for events of type T on b.
+func Subscribe[T any](b *Bus, handler func(T)) Subscription {
+	return topicFor[T](b).Subscribe(handler)
+}
+
+// SubscribeOnce registers a handler for events of type T on b that is
+// removed after it fires once.
+func SubscribeOnce[T any](b *Bus, handler func(T)) Subscription {
+	return topicFor[T](b).SubscribeOnce(handler)
+}
+
+// SubscribeChan returns a channel that receives every value of type T
+// emitted on b. See Topic.SubscribeChan.
+func SubscribeChan[T any](b *Bus, buf int) (<-chan T, Subscription) {
+	return topicFor[T](b).SubscribeChan(buf)
+}
+
+// Emit fires every handler subscribed to T on b asynchronously.
+func Emit[T any](b *Bus, val T) {
+	topicFor[T](b).Emit(val)
+}
+
+// EmitSync fires every handler subscribed to T on b synchronously.
+func EmitSync[T any](b *Bus, val T) {
+	topicFor[T](b).EmitSync(val)
+}
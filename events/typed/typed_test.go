@@ -0,0 +1,215 @@
+package typed_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wadeAlexC/go-events/events/typed"
+)
+
+type tEvent struct{ N int }
+
+// TestTopicEmitSync verifies that EmitSync calls every subscribed
+// handler, in registration order, with the emitted value.
+func TestTopicEmitSync(t *testing.T) {
+	topic := typed.NewTopic[tEvent]()
+
+	var mu sync.Mutex
+	var got []int
+
+	topic.Subscribe(func(e tEvent) {
+		mu.Lock()
+		got = append(got, e.N)
+		mu.Unlock()
+	})
+	topic.Subscribe(func(e tEvent) {
+		mu.Lock()
+		got = append(got, e.N*10)
+		mu.Unlock()
+	})
+
+	topic.EmitSync(tEvent{N: 1})
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []int{1, 10}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// TestTopicSubscribeOnce verifies a SubscribeOnce handler fires
+// exactly once, even across repeated emits.
+func TestTopicSubscribeOnce(t *testing.T) {
+	topic := typed.NewTopic[tEvent]()
+
+	var mu sync.Mutex
+	fires := 0
+
+	topic.SubscribeOnce(func(tEvent) {
+		mu.Lock()
+		fires++
+		mu.Unlock()
+	})
+
+	topic.EmitSync(tEvent{})
+	topic.EmitSync(tEvent{})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fires != 1 {
+		t.Fatalf("handler fired %d times, want 1", fires)
+	}
+}
+
+// TestTopicUnsubscribe verifies that Unsubscribe detaches a handler so
+// it no longer fires.
+func TestTopicUnsubscribe(t *testing.T) {
+	topic := typed.NewTopic[tEvent]()
+
+	var mu sync.Mutex
+	fires := 0
+
+	sub := topic.Subscribe(func(tEvent) {
+		mu.Lock()
+		fires++
+		mu.Unlock()
+	})
+	sub.Unsubscribe()
+
+	topic.EmitSync(tEvent{})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fires != 0 {
+		t.Fatalf("handler fired %d times after Unsubscribe, want 0", fires)
+	}
+}
+
+// TestTopicEmitAsync verifies that Emit delivers to handlers even
+// though each runs on its own goroutine.
+func TestTopicEmitAsync(t *testing.T) {
+	topic := typed.NewTopic[tEvent]()
+
+	done := make(chan int, 1)
+	topic.Subscribe(func(e tEvent) { done <- e.N })
+
+	topic.Emit(tEvent{N: 7})
+
+	select {
+	case n := <-done:
+		if n != 7 {
+			t.Fatalf("got %d, want 7", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Emit never delivered to the handler")
+	}
+}
+
+// TestTopicSubscribeChan verifies SubscribeChan delivers emitted
+// values on the returned channel, and that Unsubscribe closes it.
+func TestTopicSubscribeChan(t *testing.T) {
+	topic := typed.NewTopic[tEvent]()
+
+	ch, sub := topic.SubscribeChan(1)
+	topic.EmitSync(tEvent{N: 3})
+
+	select {
+	case v := <-ch:
+		if v.N != 3 {
+			t.Fatalf("got %+v, want N=3", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("SubscribeChan never delivered the emitted value")
+	}
+
+	sub.Unsubscribe()
+
+	if _, ok := <-ch; ok {
+		t.Fatal("channel still open after Unsubscribe")
+	}
+}
+
+// TestTopicSubscribeChanDropsOnFull verifies that a full SubscribeChan
+// channel drops the value instead of blocking EmitSync - Topic has no
+// worker pool or OverflowPolicy to fall back on, per SubscribeChan's
+// doc comment.
+func TestTopicSubscribeChanDropsOnFull(t *testing.T) {
+	topic := typed.NewTopic[tEvent]()
+
+	ch, _ := topic.SubscribeChan(1)
+	topic.EmitSync(tEvent{N: 1}) // fills the one-slot buffer
+
+	done := make(chan struct{})
+	go func() {
+		topic.EmitSync(tEvent{N: 2})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("EmitSync blocked on a full SubscribeChan channel")
+	}
+
+	if v := <-ch; v.N != 1 {
+		t.Fatalf("got N=%d, want the first emitted value (N=1)", v.N)
+	}
+}
+
+// TestBusRoutesByType verifies that a Bus dispatches each value only
+// to handlers subscribed to that value's type.
+func TestBusRoutesByType(t *testing.T) {
+	type eventA struct{ S string }
+	type eventB struct{ N int }
+
+	bus := typed.NewBus()
+
+	var mu sync.Mutex
+	var aFires, bFires int
+
+	typed.Subscribe(bus, func(eventA) {
+		mu.Lock()
+		aFires++
+		mu.Unlock()
+	})
+	typed.Subscribe(bus, func(eventB) {
+		mu.Lock()
+		bFires++
+		mu.Unlock()
+	})
+
+	typed.EmitSync(bus, eventA{S: "hi"})
+	typed.EmitSync(bus, eventB{N: 1})
+	typed.EmitSync(bus, eventB{N: 2})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if aFires != 1 {
+		t.Fatalf("eventA handler fired %d times, want 1", aFires)
+	}
+	if bFires != 2 {
+		t.Fatalf("eventB handler fired %d times, want 2", bFires)
+	}
+}
+
+// TestBusSubscribeChan verifies the package-level SubscribeChan wires
+// up to the right per-type Topic on the Bus.
+func TestBusSubscribeChan(t *testing.T) {
+	type eventC struct{ N int }
+
+	bus := typed.NewBus()
+	ch, _ := typed.SubscribeChan[eventC](bus, 1)
+
+	typed.EmitSync(bus, eventC{N: 9})
+
+	select {
+	case v := <-ch:
+		if v.N != 9 {
+			t.Fatalf("got %+v, want N=9", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("SubscribeChan never delivered the emitted value")
+	}
+}